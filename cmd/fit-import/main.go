@@ -0,0 +1,34 @@
+// Command fit-import reads a FIT file and prints the same training summary
+// ShowTrainingInfo produces, once per session the file contains.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"ftracker/fit"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: fit-import <path-to.fit>")
+		os.Exit(1)
+	}
+
+	f, err := os.Open(os.Args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fit-import:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	trainings, err := fit.Parse(f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fit-import:", err)
+		os.Exit(1)
+	}
+
+	for _, t := range trainings {
+		fmt.Print(t.Info())
+	}
+}
@@ -0,0 +1,347 @@
+// Package fit parses Garmin/ANT FIT files and converts the session messages
+// they contain into ftracker.Training values the existing calorie functions
+// can consume.
+//
+// The full FIT binary profile (variable message definitions, developer
+// fields, compressed timestamp headers, …) is large. This package decodes
+// the file header plus the lap and session mesgs that matter for this
+// module — distance, elapsed time, calories and sport. Every other mesg,
+// record mesgs included, is read and skipped. Compressed timestamp headers,
+// developer fields and fields wider than 4 bytes are reported as an error
+// rather than silently misread.
+package fit
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"ftracker"
+	"ftracker/strutil"
+)
+
+// Global FIT message numbers this package understands, per the FIT SDK profile.
+const (
+	mesgNumSession uint16 = 18
+	mesgNumLap     uint16 = 19
+	mesgNumRecord  uint16 = 20
+)
+
+// Field numbers within the mesgs above, per the FIT SDK profile.
+const (
+	fieldDistance        byte = 5  // record/lap: cumulative/total distance, cm.
+	fieldSport           byte = 5  // session: sport enum.
+	fieldSessionDistance byte = 9  // session: total distance, cm.
+	fieldTotalElapsed    byte = 7  // lap/session: total elapsed time, in 1/1000 s.
+	fieldTotalCalories   byte = 11 // lap/session: total calories.
+)
+
+// Sport enum values, per the FIT SDK profile, that this package can map to a training summary.
+const (
+	sportRunning  = 1
+	sportCycling  = 2
+	sportSwimming = 5
+	sportRowing   = 15
+)
+
+const (
+	mInKm  = 1000 // meters in a kilometer.
+	secInH = 3600 // seconds in an hour.
+)
+
+// Lap holds the summary fields decoded from a single FIT lap message.
+type Lap struct {
+	DistanceMeters float64
+	ElapsedSeconds float64
+	Calories       float64
+}
+
+// Session is a single FIT activity: its overall totals plus the laps it is made up of.
+type Session struct {
+	Sport          int
+	DistanceMeters float64
+	ElapsedSeconds float64
+	Calories       float64
+	Laps           []Lap
+}
+
+// Training adapts a decoded FIT Session to the ftracker.Training interface,
+// reporting the totals recorded by the device rather than recomputing them
+// from step or stroke counts, which FIT files don't carry.
+type Training struct {
+	Session Session
+}
+
+// Type returns the session's sport, mapped to the display name ftracker's
+// built-in training kinds use.
+func (t Training) Type() string { return sportName(t.Session.Sport) }
+
+// Duration returns the session duration, in hours.
+func (t Training) Duration() float64 { return t.Session.ElapsedSeconds / secInH }
+
+// Distance returns the session distance, in kilometers.
+func (t Training) Distance() float64 { return t.Session.DistanceMeters / mInKm }
+
+// MeanSpeed returns the session average speed, in km/h.
+func (t Training) MeanSpeed() float64 {
+	if t.Duration() == 0 {
+		return 0
+	}
+
+	return t.Distance() / t.Duration()
+}
+
+// SpentCalories returns the calories the device recorded for the session.
+func (t Training) SpentCalories() float64 { return t.Session.Calories }
+
+// Info returns a human-readable summary of the session, in the same format
+// ShowTrainingInfo produces.
+func (t Training) Info() string {
+	return fmt.Sprintf("Тип тренировки: %s\nДлительность: %.2f ч.\nДистанция: %.2f км.\nСкорость: %.2f км/ч\nСожгли калорий: %.2f\n",
+		t.Type(), t.Duration(), t.Distance(), t.MeanSpeed(), t.SpentCalories())
+}
+
+func sportName(sport int) string {
+	switch sport {
+	case sportRunning:
+		return "Бег"
+	case sportCycling:
+		return "Велоспорт"
+	case sportSwimming:
+		return "Плавание"
+	case sportRowing:
+		return "Гребля"
+	default:
+		return "неизвестный тип тренировки"
+	}
+}
+
+// definition describes how to decode the data messages that follow it for a
+// given local message type, per the FIT binary protocol.
+type definition struct {
+	globalMesgNum uint16
+	byteOrder     binary.ByteOrder
+	fields        []fieldDef
+}
+
+type fieldDef struct {
+	num  byte
+	size byte
+}
+
+// Parse reads a FIT file from r and returns one ftracker.Training per
+// session message it contains.
+//
+// *** parameters ***
+//
+// r io.Reader — the FIT file contents, including its file header.
+func Parse(r io.Reader) ([]ftracker.Training, error) {
+	sessions, err := parseSessions(r)
+	if err != nil {
+		return nil, err
+	}
+
+	trainings := make([]ftracker.Training, 0, len(sessions))
+	for _, s := range sessions {
+		trainings = append(trainings, Training{Session: s})
+	}
+
+	return trainings, nil
+}
+
+// ProcessLaps converts decoded laps into per-lap summaries: distance in km,
+// elapsed time in hours, average pace in decimal minutes per km, and
+// calories — the same breakdown the external fitplot example prints per lap.
+func ProcessLaps(laps []Lap) (distances, times, paces, calories []float64) {
+	for _, l := range laps {
+		km := l.DistanceMeters / mInKm
+		hours := l.ElapsedSeconds / secInH
+		distances = append(distances, km)
+		times = append(times, hours)
+		calories = append(calories, l.Calories)
+
+		if km == 0 {
+			paces = append(paces, 0)
+			continue
+		}
+		paces = append(paces, (l.ElapsedSeconds/60)/km)
+	}
+
+	return distances, times, paces, calories
+}
+
+// FormatPace renders a decimal-minutes pace, as returned by ProcessLaps, as "M:SS".
+func FormatPace(decimalMinutes float64) string {
+	return strutil.DecimalTimeToMinSec(decimalMinutes)
+}
+
+// parseSessions decodes every session mesg in the file, along with the lap
+// mesgs that precede it.
+func parseSessions(r io.Reader) ([]Session, error) {
+	dataSize, err := skipFileHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	data := io.LimitReader(r, int64(dataSize))
+
+	defs := make(map[byte]definition)
+	var pendingLaps []Lap
+	var sessions []Session
+
+	for {
+		header, err := readByte(data)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if header&0x80 != 0 {
+			return nil, fmt.Errorf("fit: compressed timestamp headers are not supported")
+		}
+
+		localType := header & 0x0F
+		isDefinition := header&0x40 != 0
+
+		if isDefinition {
+			if header&0x20 != 0 {
+				return nil, fmt.Errorf("fit: developer data fields are not supported")
+			}
+			def, err := readDefinition(data)
+			if err != nil {
+				return nil, err
+			}
+			defs[localType] = def
+			continue
+		}
+
+		def, ok := defs[localType]
+		if !ok {
+			return nil, fmt.Errorf("fit: data message for undefined local type %d", localType)
+		}
+
+		raw, err := readDataFields(data, def)
+		if err != nil {
+			return nil, err
+		}
+
+		switch def.globalMesgNum {
+		case mesgNumLap:
+			pendingLaps = append(pendingLaps, Lap{
+				DistanceMeters: float64(raw[fieldDistance]) / 100,
+				ElapsedSeconds: float64(raw[fieldTotalElapsed]) / 1000,
+				Calories:       float64(raw[fieldTotalCalories]),
+			})
+		case mesgNumSession:
+			sessions = append(sessions, Session{
+				Sport:          int(raw[fieldSport]),
+				DistanceMeters: float64(raw[fieldSessionDistance]) / 100,
+				ElapsedSeconds: float64(raw[fieldTotalElapsed]) / 1000,
+				Calories:       float64(raw[fieldTotalCalories]),
+				Laps:           pendingLaps,
+			})
+			pendingLaps = nil
+		}
+	}
+
+	return sessions, nil
+}
+
+// skipFileHeader reads past the file header and returns the data size it
+// declares: the number of record/definition bytes that follow, not counting
+// the trailing 2-byte file CRC. Per the FIT binary protocol the header is
+// size[0] bytes long, with the data size as a little-endian uint32 three
+// bytes into the rest (protocol version, then a 2-byte profile version,
+// then the data size).
+func skipFileHeader(r io.Reader) (uint32, error) {
+	var size [1]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return 0, fmt.Errorf("fit: reading header size: %w", err)
+	}
+
+	rest := make([]byte, size[0]-1)
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return 0, fmt.Errorf("fit: reading header: %w", err)
+	}
+	if len(rest) < 7 {
+		return 0, fmt.Errorf("fit: header too short to contain a data size")
+	}
+
+	return binary.LittleEndian.Uint32(rest[3:7]), nil
+}
+
+func readByte(r io.Reader) (byte, error) {
+	var b [1]byte
+	_, err := io.ReadFull(r, b[:])
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+
+	return b[0], err
+}
+
+func readDefinition(r io.Reader) (definition, error) {
+	buf := make([]byte, 5)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return definition{}, fmt.Errorf("fit: reading definition header: %w", err)
+	}
+
+	byteOrder := binary.ByteOrder(binary.LittleEndian)
+	if buf[1] == 1 {
+		byteOrder = binary.BigEndian
+	}
+	globalMesgNum := byteOrder.Uint16(buf[2:4])
+	numFields := buf[4]
+
+	fields := make([]fieldDef, numFields)
+	fieldBuf := make([]byte, 3)
+	for i := range fields {
+		if _, err := io.ReadFull(r, fieldBuf); err != nil {
+			return definition{}, fmt.Errorf("fit: reading field definition: %w", err)
+		}
+		fields[i] = fieldDef{num: fieldBuf[0], size: fieldBuf[1]}
+	}
+
+	return definition{globalMesgNum: globalMesgNum, byteOrder: byteOrder, fields: fields}, nil
+}
+
+func readDataFields(r io.Reader, def definition) (map[byte]uint32, error) {
+	values := make(map[byte]uint32, len(def.fields))
+	for _, f := range def.fields {
+		buf := make([]byte, f.size)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, fmt.Errorf("fit: reading field %d: %w", f.num, err)
+		}
+		v, err := decodeUint(buf, def.byteOrder)
+		if err != nil {
+			return nil, fmt.Errorf("fit: field %d: %w", f.num, err)
+		}
+		values[f.num] = v
+	}
+
+	return values, nil
+}
+
+// decodeUint assembles a field value into a uint32, honoring the
+// definition's architecture byte. Every field this package reads (distance,
+// elapsed time, calories, sport) is a FIT uint8/uint16/uint32 base type, so
+// 4 bytes is always enough; larger base types (uint64, float64, strings)
+// aren't among the fields it looks up and are rejected rather than silently
+// truncated.
+func decodeUint(buf []byte, order binary.ByteOrder) (uint32, error) {
+	if len(buf) > 4 {
+		return 0, fmt.Errorf("field size %d exceeds the uint32 fixed-width fields this package decodes", len(buf))
+	}
+
+	var v uint32
+	for i, b := range buf {
+		shift := i
+		if order == binary.BigEndian {
+			shift = len(buf) - 1 - i
+		}
+		v |= uint32(b) << (8 * shift)
+	}
+
+	return v, nil
+}
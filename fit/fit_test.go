@@ -0,0 +1,233 @@
+package fit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// fitField is one field of a fixture definition message: a FIT field number,
+// its encoded size in bytes, and the base type byte (unused by this
+// package's decoder, but required by the wire format).
+type fitField struct {
+	num     byte
+	size    byte
+	baseTyp byte
+}
+
+// buildFixture assembles a minimal well-formed FIT file: a 14-byte header
+// followed by one definition message and one data message per mesg, and a
+// trailing 2-byte file CRC the decoder must not try to parse as a record.
+// Multi-byte values are encoded little-endian unless bigEndian is set, in
+// which case the definition's architecture byte is set to match.
+func buildFixture(t *testing.T, bigEndian bool, mesgs []struct {
+	globalMesgNum uint16
+	localType     byte
+	fields        []fitField
+	values        map[byte]uint32
+}) []byte {
+	t.Helper()
+
+	order := binary.ByteOrder(binary.LittleEndian)
+	architecture := byte(0)
+	if bigEndian {
+		order = binary.BigEndian
+		architecture = 1
+	}
+
+	var data bytes.Buffer
+	for _, m := range mesgs {
+		data.WriteByte(0x40 | m.localType)  // definition message header
+		data.Write([]byte{0, architecture}) // reserved, architecture
+		binary.Write(&data, order, m.globalMesgNum)
+		data.WriteByte(byte(len(m.fields)))
+		for _, f := range m.fields {
+			data.Write([]byte{f.num, f.size, f.baseTyp})
+		}
+
+		data.WriteByte(m.localType) // data message header
+		for _, f := range m.fields {
+			buf := make([]byte, f.size)
+			v := m.values[f.num]
+			for i := range buf {
+				shift := i
+				if bigEndian {
+					shift = len(buf) - 1 - i
+				}
+				buf[i] = byte(v >> (8 * shift))
+			}
+			data.Write(buf)
+		}
+	}
+
+	var file bytes.Buffer
+	file.WriteByte(14)                                           // header size
+	file.WriteByte(0x10)                                         // protocol version
+	file.Write([]byte{0, 0})                                     // profile version
+	binary.Write(&file, binary.LittleEndian, uint32(data.Len())) // data size
+	file.WriteString(".FIT")
+	file.Write([]byte{0, 0}) // header CRC, unchecked by this package
+	file.Write(data.Bytes())
+	file.Write([]byte{0xAB, 0xCD}) // trailing file CRC
+
+	return file.Bytes()
+}
+
+func TestParseSessionWithLap(t *testing.T) {
+	lapFields := []fitField{
+		{fieldDistance, 4, 0x86},
+		{fieldTotalElapsed, 4, 0x86},
+		{fieldTotalCalories, 2, 0x84},
+	}
+	sessionFields := []fitField{
+		{fieldSport, 1, 0x02},
+		{fieldSessionDistance, 4, 0x86},
+		{fieldTotalElapsed, 4, 0x86},
+		{fieldTotalCalories, 2, 0x84},
+	}
+
+	file := buildFixture(t, false, []struct {
+		globalMesgNum uint16
+		localType     byte
+		fields        []fitField
+		values        map[byte]uint32
+	}{
+		{mesgNumLap, 0, lapFields, map[byte]uint32{
+			fieldDistance:      250000, // 2500.00 m
+			fieldTotalElapsed:  900000, // 900 s
+			fieldTotalCalories: 120,
+		}},
+		{mesgNumSession, 1, sessionFields, map[byte]uint32{
+			fieldSport:           sportRunning,
+			fieldSessionDistance: 500000,  // 5000.00 m
+			fieldTotalElapsed:    1800000, // 1800 s
+			fieldTotalCalories:   300,
+		}},
+	})
+
+	trainings, err := Parse(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(trainings) != 1 {
+		t.Fatalf("len(trainings) = %d, want 1", len(trainings))
+	}
+
+	tr := trainings[0]
+	if got, want := tr.Type(), "Бег"; got != want {
+		t.Errorf("Type() = %q, want %q", got, want)
+	}
+	if got, want := tr.Distance(), 5.0; got != want {
+		t.Errorf("Distance() = %v, want %v", got, want)
+	}
+	if got, want := tr.Duration(), 0.5; got != want {
+		t.Errorf("Duration() = %v, want %v", got, want)
+	}
+	if got, want := tr.SpentCalories(), 300.0; got != want {
+		t.Errorf("SpentCalories() = %v, want %v", got, want)
+	}
+
+	session := tr.(Training).Session
+	if len(session.Laps) != 1 {
+		t.Fatalf("len(session.Laps) = %d, want 1", len(session.Laps))
+	}
+	if got, want := session.Laps[0].DistanceMeters, 2500.0; got != want {
+		t.Errorf("lap distance = %v, want %v", got, want)
+	}
+}
+
+func TestParseSessionBigEndian(t *testing.T) {
+	sessionFields := []fitField{
+		{fieldSport, 1, 0x02},
+		{fieldSessionDistance, 4, 0x86},
+		{fieldTotalElapsed, 4, 0x86},
+		{fieldTotalCalories, 2, 0x84},
+	}
+
+	file := buildFixture(t, true, []struct {
+		globalMesgNum uint16
+		localType     byte
+		fields        []fitField
+		values        map[byte]uint32
+	}{
+		{mesgNumSession, 0, sessionFields, map[byte]uint32{
+			fieldSport:           sportCycling,
+			fieldSessionDistance: 1000000, // 10000.00 m
+			fieldTotalElapsed:    3600000, // 3600 s
+			fieldTotalCalories:   400,
+		}},
+	})
+
+	trainings, err := Parse(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(trainings) != 1 {
+		t.Fatalf("len(trainings) = %d, want 1", len(trainings))
+	}
+
+	tr := trainings[0]
+	if got, want := tr.Type(), "Велоспорт"; got != want {
+		t.Errorf("Type() = %q, want %q", got, want)
+	}
+	if got, want := tr.Distance(), 10.0; got != want {
+		t.Errorf("Distance() = %v, want %v", got, want)
+	}
+	if got, want := tr.SpentCalories(), 400.0; got != want {
+		t.Errorf("SpentCalories() = %v, want %v", got, want)
+	}
+}
+
+func TestParseRejectsCompressedTimestampHeader(t *testing.T) {
+	var file bytes.Buffer
+	file.WriteByte(14)
+	file.WriteByte(0x10)
+	file.Write([]byte{0, 0})
+	binary.Write(&file, binary.LittleEndian, uint32(1))
+	file.WriteString(".FIT")
+	file.Write([]byte{0, 0})
+	file.WriteByte(0x80) // compressed timestamp header
+
+	if _, err := Parse(&file); err == nil {
+		t.Fatal("Parse() error = nil, want an error for a compressed timestamp header")
+	}
+}
+
+func TestParseRejectsUndefinedLocalType(t *testing.T) {
+	var file bytes.Buffer
+	file.WriteByte(14)
+	file.WriteByte(0x10)
+	file.Write([]byte{0, 0})
+	binary.Write(&file, binary.LittleEndian, uint32(1))
+	file.WriteString(".FIT")
+	file.Write([]byte{0, 0})
+	file.WriteByte(0x05) // data message header for a local type with no definition
+
+	if _, err := Parse(&file); err == nil {
+		t.Fatal("Parse() error = nil, want an error for an undefined local type")
+	}
+}
+
+func TestDecodeUintRejectsOversizedFields(t *testing.T) {
+	if _, err := decodeUint(make([]byte, 8), binary.LittleEndian); err == nil {
+		t.Fatal("decodeUint(8 bytes) error = nil, want an error")
+	}
+
+	v, err := decodeUint([]byte{0x01, 0x02, 0x03, 0x04}, binary.LittleEndian)
+	if err != nil {
+		t.Fatalf("decodeUint(4 bytes) error = %v", err)
+	}
+	if want := uint32(0x04030201); v != want {
+		t.Errorf("decodeUint() = %#x, want %#x", v, want)
+	}
+}
+
+func TestDecodeUintHonorsByteOrder(t *testing.T) {
+	v, err := decodeUint([]byte{0x01, 0x02, 0x03, 0x04}, binary.BigEndian)
+	if err != nil {
+		t.Fatalf("decodeUint(big-endian) error = %v", err)
+	}
+	if want := uint32(0x01020304); v != want {
+		t.Errorf("decodeUint(big-endian) = %#x, want %#x", v, want)
+	}
+}
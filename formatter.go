@@ -0,0 +1,158 @@
+package ftracker
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Formatter renders a TrainingResult as text.
+type Formatter interface {
+	Format(TrainingResult) string
+}
+
+// TextFormatter renders a TrainingResult as the Russian-language report
+// ShowTrainingInfo has always produced.
+type TextFormatter struct {
+	Units Units
+}
+
+// Format implements Formatter.
+func (f TextFormatter) Format(r TrainingResult) string {
+	report := fmt.Sprintf("Тип тренировки: %s\nДлительность: %.2f ч.\n", r.Type, r.Duration)
+	if !r.Locationless {
+		report += fmt.Sprintf("Дистанция: %.2f %s.\nСкорость: %.2f %s\n",
+			convertDistance(r.Distance, f.Units), ruDistanceUnit(f.Units), convertSpeed(r.Speed, f.Units), ruSpeedUnit(f.Units))
+	}
+	report += fmt.Sprintf("Сожгли калорий: %.2f\n", r.Calories)
+	report += ruExtra(r.Extra)
+
+	return report
+}
+
+func ruDistanceUnit(u Units) string {
+	if u == Imperial {
+		return "миль"
+	}
+
+	return "км"
+}
+
+func ruSpeedUnit(u Units) string {
+	if u == Imperial {
+		return "миль/ч"
+	}
+
+	return "км/ч"
+}
+
+func ruExtra(extra map[string]any) string {
+	if extra == nil {
+		return ""
+	}
+
+	report := fmt.Sprintf("Средний пульс: %.0f уд/мин\nМаксимальный пульс: %d уд/мин\nСожгли калорий (по пульсу): %.2f\n",
+		extra["avgBPM"], extra["maxBPM"], extra["hrCalories"])
+
+	zones, ok := extra["zones"].([5]float64)
+	if !ok {
+		return report
+	}
+
+	zoneNames := [5]string{"Z1", "Z2", "Z3", "Z4", "Z5"}
+	for i, seconds := range zones {
+		report += fmt.Sprintf("%s: %.0f сек.\n", zoneNames[i], seconds)
+	}
+
+	return report
+}
+
+// EnglishTextFormatter renders a TrainingResult the same way TextFormatter
+// does, but in English.
+type EnglishTextFormatter struct {
+	Units Units
+}
+
+// Format implements Formatter.
+func (f EnglishTextFormatter) Format(r TrainingResult) string {
+	report := fmt.Sprintf("Training type: %s\nDuration: %.2f h.\n", r.Type, r.Duration)
+	if !r.Locationless {
+		report += fmt.Sprintf("Distance: %.2f %s.\nSpeed: %.2f %s\n",
+			convertDistance(r.Distance, f.Units), enDistanceUnit(f.Units), convertSpeed(r.Speed, f.Units), enSpeedUnit(f.Units))
+	}
+	report += fmt.Sprintf("Calories burned: %.2f\n", r.Calories)
+	report += enExtra(r.Extra)
+
+	return report
+}
+
+func enDistanceUnit(u Units) string {
+	if u == Imperial {
+		return "mi"
+	}
+
+	return "km"
+}
+
+func enSpeedUnit(u Units) string {
+	if u == Imperial {
+		return "mph"
+	}
+
+	return "km/h"
+}
+
+func enExtra(extra map[string]any) string {
+	if extra == nil {
+		return ""
+	}
+
+	report := fmt.Sprintf("Average heart rate: %.0f bpm\nMax heart rate: %d bpm\nCalories burned (by heart rate): %.2f\n",
+		extra["avgBPM"], extra["maxBPM"], extra["hrCalories"])
+
+	zones, ok := extra["zones"].([5]float64)
+	if !ok {
+		return report
+	}
+
+	zoneNames := [5]string{"Z1", "Z2", "Z3", "Z4", "Z5"}
+	for i, seconds := range zones {
+		report += fmt.Sprintf("%s: %.0f sec.\n", zoneNames[i], seconds)
+	}
+
+	return report
+}
+
+// JSONFormatter renders a TrainingResult as JSON.
+type JSONFormatter struct{}
+
+// Format implements Formatter.
+func (f JSONFormatter) Format(r TrainingResult) string {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err)
+	}
+
+	return string(b)
+}
+
+// MarkdownFormatter renders a TrainingResult as a Markdown bullet list.
+type MarkdownFormatter struct {
+	Units Units
+}
+
+// Format implements Formatter.
+func (f MarkdownFormatter) Format(r TrainingResult) string {
+	report := fmt.Sprintf("- **Type**: %s\n- **Duration**: %.2f h\n", r.Type, r.Duration)
+	if !r.Locationless {
+		report += fmt.Sprintf("- **Distance**: %.2f %s\n- **Speed**: %.2f %s\n",
+			convertDistance(r.Distance, f.Units), enDistanceUnit(f.Units), convertSpeed(r.Speed, f.Units), enSpeedUnit(f.Units))
+	}
+	report += fmt.Sprintf("- **Calories**: %.2f\n", r.Calories)
+
+	if r.Extra != nil {
+		report += fmt.Sprintf("- **Avg HR**: %.0f bpm\n- **Max HR**: %d bpm\n- **HR calories**: %.2f\n",
+			r.Extra["avgBPM"], r.Extra["maxBPM"], r.Extra["hrCalories"])
+	}
+
+	return report
+}
@@ -5,10 +5,7 @@
 // It provides to get an information from sensors and calculate spent calories during training session.
 package ftracker
 
-import (
-	"fmt"
-	"math"
-)
+import "math"
 
 // Main constants needs for calculations.
 const (
@@ -61,26 +58,26 @@ func meanSpeed(action int, duration float64) float64 {
 // lengthPool int — pool length in meters.
 //
 // countPool int — how many times the user swam across the pool.
-func ShowTrainingInfo(action int, trainingType string, duration, weight, height float64, lengthPool, countPool int) string {
-	switch {
-	case trainingType == "Бег":
-		distance := distance(action)
-		speed := meanSpeed(action, duration)
-		calories := RunningSpentCalories(action, weight, duration)
-		return fmt.Sprintf("Тип тренировки: %s\nДлительность: %.2f ч.\nДистанция: %.2f км.\nСкорость: %.2f км/ч\nСожгли калорий: %.2f\n", trainingType, duration, distance, speed, calories)
-	case trainingType == "Ходьба":
-		distance := distance(action)
-		speed := meanSpeed(action, duration)
-		calories := WalkingSpentCalories(action, duration, weight, height)
-		return fmt.Sprintf("Тип тренировки: %s\nДлительность: %.2f ч.\nДистанция: %.2f км.\nСкорость: %.2f км/ч\nСожгли калорий: %.2f\n", trainingType, duration, distance, speed, calories)
-	case trainingType == "Плавание":
-		distance := distance(action)
-		speed := swimmingMeanSpeed(lengthPool, countPool, duration)
-		calories := SwimmingSpentCalories(lengthPool, countPool, duration, weight)
-		return fmt.Sprintf("Тип тренировки: %s\nДлительность: %.2f ч.\nДистанция: %.2f км.\nСкорость: %.2f км/ч\nСожгли калорий: %.2f\n", trainingType, duration, distance, speed, calories)
+//
+// hr *HRInfo — optional heart-rate samples and biometrics; when non-nil and holding at least one sample, average/max bpm and a zone breakdown are appended to the report.
+//
+// ShowTrainingInfo is a thin wrapper around Compute and TextFormatter, kept
+// for callers that just want the classic Russian-language report.
+func ShowTrainingInfo(action int, trainingType string, duration, weight, height float64, lengthPool, countPool int, hr *HRInfo) string {
+	var training Training
+
+	switch trainingType {
+	case "Бег":
+		training = Running{Action: action, DurationHours: duration, Weight: weight}
+	case "Ходьба":
+		training = Walking{Action: action, DurationHours: duration, Weight: weight, Height: height}
+	case "Плавание":
+		training = Swimming{LengthPool: lengthPool, CountPool: countPool, DurationHours: duration, Weight: weight}
 	default:
 		return "неизвестный тип тренировки"
 	}
+
+	return TextFormatter{}.Format(Compute(training, hr))
 }
 
 // Constants for calculating calories consumed during running.
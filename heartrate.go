@@ -0,0 +1,188 @@
+package ftracker
+
+import (
+	"time"
+)
+
+// Sex distinguishes which Keytel regression coefficients to use when
+// estimating calories from heart rate.
+type Sex int
+
+// Supported values for Sex.
+const (
+	SexMale Sex = iota
+	SexFemale
+)
+
+// HRSample is a single heart-rate reading taken during a training session.
+type HRSample struct {
+	Timestamp time.Time
+	BPM       int
+}
+
+// HRInfo bundles heart-rate samples with the biometrics the Keytel formula,
+// the zone bands and TRIMP need: weight, age, sex, resting and maximum heart rate.
+type HRInfo struct {
+	Samples []HRSample
+	Weight  float64
+	Age     float64
+	Sex     Sex
+	RestHR  float64
+	MaxHR   float64
+}
+
+// restingHRThreshold is the bpm below which a heart-rate interval is treated
+// as rest and excluded from the calorie estimate.
+const restingHRThreshold = 50
+
+// hrZoneBounds are the lower %HRmax bounds of zones Z1..Z5.
+var hrZoneBounds = [5]float64{50, 60, 70, 80, 90}
+
+// HeartRateSpentCalories estimates calories spent from a series of heart-rate
+// samples using the Keytel regression, rather than pace. For each interval
+// between samples it computes kcal/min from the average bpm, weight and age,
+// multiplies by the interval length in minutes, and sums the result. Interval
+// heart rate below restingHRThreshold is skipped, and negative per-minute
+// estimates are clamped to zero.
+//
+// *** parameters ***
+//
+// samples []HRSample — heart-rate samples taken during the session, in time order.
+//
+// weight float64 — user weight in kg.
+//
+// age float64 — user age in years.
+//
+// sex Sex — user sex, selecting which Keytel coefficients to use.
+//
+// duration float64 — training duration in hours, used as a fallback when exactly one sample is given; with no samples the result is 0.
+func HeartRateSpentCalories(samples []HRSample, weight, age float64, sex Sex, duration float64) float64 {
+	if len(samples) == 1 {
+		perMinute := kcalPerMinute(float64(samples[0].BPM), weight, age, sex)
+		if perMinute < 0 {
+			perMinute = 0
+		}
+
+		return perMinute * duration * minInH
+	}
+
+	var total float64
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1], samples[i]
+
+		minutes := cur.Timestamp.Sub(prev.Timestamp).Minutes()
+		if minutes <= 0 {
+			continue
+		}
+
+		avgBPM := float64(prev.BPM+cur.BPM) / 2
+		if avgBPM < restingHRThreshold {
+			continue
+		}
+
+		perMinute := kcalPerMinute(avgBPM, weight, age, sex)
+		if perMinute < 0 {
+			perMinute = 0
+		}
+
+		total += perMinute * minutes
+	}
+
+	return total
+}
+
+// kcalPerMinute implements the Keytel regression: coefficients for men are
+// (−55.0969 + 0.6309·HR + 0.1988·weight + 0.2017·age)/4.184, and for women
+// (−20.4022 + 0.4472·HR − 0.1263·weight + 0.074·age)/4.184.
+func kcalPerMinute(bpm, weight, age float64, sex Sex) float64 {
+	if sex == SexFemale {
+		return (-20.4022 + 0.4472*bpm - 0.1263*weight + 0.074*age) / 4.184
+	}
+
+	return (-55.0969 + 0.6309*bpm + 0.1988*weight + 0.2017*age) / 4.184
+}
+
+// HeartRateZones returns the seconds spent in each of the five %HRmax bands
+// (Z1 50-60%, Z2 60-70%, Z3 70-80%, Z4 80-90%, Z5 90%+), given the samples
+// and the user's maximum heart rate.
+//
+// *** parameters ***
+//
+// samples []HRSample — heart-rate samples taken during the session, in time order.
+//
+// maxHR float64 — the user's maximum heart rate in bpm.
+func HeartRateZones(samples []HRSample, maxHR float64) [5]float64 {
+	var zones [5]float64
+	if maxHR == 0 {
+		return zones
+	}
+
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1], samples[i]
+
+		seconds := cur.Timestamp.Sub(prev.Timestamp).Seconds()
+		if seconds <= 0 {
+			continue
+		}
+
+		avgBPM := float64(prev.BPM+cur.BPM) / 2
+		if zone := hrZoneIndex(avgBPM, maxHR); zone >= 0 {
+			zones[zone] += seconds
+		}
+	}
+
+	return zones
+}
+
+// hrZoneIndex returns the zero-based zone index (Z1..Z5) for a %HRmax
+// reading, or -1 if it falls below Z1.
+func hrZoneIndex(bpm, maxHR float64) int {
+	pct := bpm / maxHR * 100
+
+	zone := -1
+	for i, bound := range hrZoneBounds {
+		if pct >= bound {
+			zone = i
+		}
+	}
+
+	return zone
+}
+
+// avgBPM returns the mean bpm across samples, or 0 if there are none.
+func avgBPM(samples []HRSample) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	var sum int
+	for _, s := range samples {
+		sum += s.BPM
+	}
+
+	return float64(sum) / float64(len(samples))
+}
+
+// maxBPM returns the highest bpm across samples.
+func maxBPM(samples []HRSample) int {
+	var max int
+	for _, s := range samples {
+		if s.BPM > max {
+			max = s.BPM
+		}
+	}
+
+	return max
+}
+
+// heartRateExtra summarizes HR samples into the average/max bpm, estimated
+// calories and zone breakdown that Compute adds to a TrainingResult's Extra
+// field when HR samples are given.
+func heartRateExtra(hr *HRInfo, duration float64) map[string]any {
+	return map[string]any{
+		"avgBPM":     avgBPM(hr.Samples),
+		"maxBPM":     maxBPM(hr.Samples),
+		"hrCalories": HeartRateSpentCalories(hr.Samples, hr.Weight, hr.Age, hr.Sex, duration),
+		"zones":      HeartRateZones(hr.Samples, hr.MaxHR),
+	}
+}
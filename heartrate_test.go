@@ -0,0 +1,96 @@
+package ftracker
+
+import (
+	"testing"
+	"time"
+)
+
+func wantKcalPerMinute(bpm, weight, age float64, sex Sex) float64 {
+	if sex == SexFemale {
+		return (-20.4022 + 0.4472*bpm - 0.1263*weight + 0.074*age) / 4.184
+	}
+
+	return (-55.0969 + 0.6309*bpm + 0.1988*weight + 0.2017*age) / 4.184
+}
+
+func TestKcalPerMinute(t *testing.T) {
+	tests := []struct {
+		name string
+		bpm  float64
+		sex  Sex
+	}{
+		{"male", 140, SexMale},
+		{"female", 140, SexFemale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := wantKcalPerMinute(tt.bpm, 70, 30, tt.sex)
+			if got := kcalPerMinute(tt.bpm, 70, 30, tt.sex); got != want {
+				t.Errorf("kcalPerMinute(%v, 70, 30, %v) = %v, want %v", tt.bpm, tt.sex, got, want)
+			}
+		})
+	}
+}
+
+func sampleAt(start time.Time, offsetMin int, bpm int) HRSample {
+	return HRSample{Timestamp: start.Add(time.Duration(offsetMin) * time.Minute), BPM: bpm}
+}
+
+func TestHeartRateSpentCalories(t *testing.T) {
+	start := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("single sample falls back to duration", func(t *testing.T) {
+		samples := []HRSample{sampleAt(start, 0, 140)}
+		want := wantKcalPerMinute(140, 70, 30, SexMale) * 0.5 * minInH
+		if got := HeartRateSpentCalories(samples, 70, 30, SexMale, 0.5); got != want {
+			t.Errorf("HeartRateSpentCalories() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("sums per-interval estimates", func(t *testing.T) {
+		samples := []HRSample{
+			sampleAt(start, 0, 140),
+			sampleAt(start, 10, 150),
+			sampleAt(start, 20, 140),
+		}
+		perInterval := wantKcalPerMinute(145, 70, 30, SexMale) * 10
+		want := perInterval + perInterval
+		if got := HeartRateSpentCalories(samples, 70, 30, SexMale, 1); got != want {
+			t.Errorf("HeartRateSpentCalories() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("skips intervals below the resting threshold", func(t *testing.T) {
+		samples := []HRSample{
+			sampleAt(start, 0, 40),
+			sampleAt(start, 10, 45),
+		}
+		if got := HeartRateSpentCalories(samples, 70, 30, SexMale, 1); got != 0 {
+			t.Errorf("HeartRateSpentCalories() = %v, want 0", got)
+		}
+	})
+
+	t.Run("clamps negative per-minute estimates to zero", func(t *testing.T) {
+		samples := []HRSample{
+			sampleAt(start, 0, 50),
+			sampleAt(start, 10, 50),
+		}
+		if wantKcalPerMinute(50, 1, 1, SexMale) >= 0 {
+			t.Fatal("test fixture no longer yields a negative estimate")
+		}
+		if got := HeartRateSpentCalories(samples, 1, 1, SexMale, 1); got != 0 {
+			t.Errorf("HeartRateSpentCalories() = %v, want 0", got)
+		}
+	})
+
+	t.Run("ignores non-increasing timestamps", func(t *testing.T) {
+		samples := []HRSample{
+			sampleAt(start, 0, 140),
+			sampleAt(start, 0, 150),
+		}
+		if got := HeartRateSpentCalories(samples, 70, 30, SexMale, 1); got != 0 {
+			t.Errorf("HeartRateSpentCalories() = %v, want 0", got)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package ftracker
+
+// TrainingResult is the structured outcome of computing a Training, kept
+// independent of how it will be rendered so any Formatter can consume it.
+type TrainingResult struct {
+	Type     string  `json:"type"`
+	Duration float64 `json:"duration"`
+	Distance float64 `json:"distance"`
+	Speed    float64 `json:"speed"`
+	Calories float64 `json:"calories"`
+
+	// Locationless is true for training kinds, such as StrengthTraining, that
+	// have no meaningful distance or speed.
+	Locationless bool `json:"locationless"`
+
+	// Extra carries optional data a Formatter may render, such as the
+	// heart-rate summary Compute adds when hr is given.
+	Extra map[string]any `json:"extra,omitempty"`
+}
+
+// Compute runs a Training and, optionally, its heart-rate samples, and
+// captures the result as a TrainingResult, the structured form Formatter
+// implementations render.
+//
+// *** parameters ***
+//
+// t Training — the training session to compute.
+//
+// hr *HRInfo — optional heart-rate samples and biometrics; when non-nil and holding at least one sample, average/max bpm, estimated calories and a zone breakdown are added to Extra.
+func Compute(t Training, hr *HRInfo) TrainingResult {
+	result := TrainingResult{
+		Type:     t.Type(),
+		Duration: t.Duration(),
+		Distance: t.Distance(),
+		Speed:    t.MeanSpeed(),
+		Calories: t.SpentCalories(),
+	}
+
+	if _, ok := t.(StrengthTraining); ok {
+		result.Locationless = true
+	}
+
+	if hr != nil && len(hr.Samples) > 0 {
+		result.Extra = heartRateExtra(hr, result.Duration)
+	}
+
+	return result
+}
@@ -0,0 +1,216 @@
+package ftracker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// LoggedWorkout pairs a computed TrainingResult with when the workout
+// happened and, optionally, the heart-rate data TrainingLoad needs. Results
+// are stored rather than live Training values so a Session round-trips
+// through LoadFromJSON/SaveJSON without needing to reconstruct trainings.
+type LoggedWorkout struct {
+	Result TrainingResult
+	Date   time.Time
+	HR     *HRInfo
+}
+
+// trimp returns the workout's Training Impulse, or 0 if it logged no
+// heart-rate data.
+func (w LoggedWorkout) trimp() float64 {
+	if w.HR == nil || w.HR.MaxHR == 0 || w.HR.MaxHR == w.HR.RestHR || len(w.HR.Samples) == 0 {
+		return 0
+	}
+
+	hrReserve := (avgBPM(w.HR.Samples) - w.HR.RestHR) / (w.HR.MaxHR - w.HR.RestHR)
+	durationMin := w.Result.Duration * minInH
+
+	if w.HR.Sex == SexFemale {
+		return durationMin * hrReserve * 0.86 * math.Exp(1.67*hrReserve)
+	}
+
+	return durationMin * hrReserve * 0.64 * math.Exp(1.92*hrReserve)
+}
+
+// Session is a training diary: an ordered collection of logged workouts
+// bounded by Start and End.
+type Session struct {
+	Workouts []LoggedWorkout
+	Start    time.Time
+	End      time.Time
+}
+
+// TotalDistance returns the combined distance of every workout, in kilometers.
+func (s Session) TotalDistance() float64 {
+	var total float64
+	for _, w := range s.Workouts {
+		total += w.Result.Distance
+	}
+
+	return total
+}
+
+// TotalCalories returns the combined calories spent across every workout.
+func (s Session) TotalCalories() float64 {
+	var total float64
+	for _, w := range s.Workouts {
+		total += w.Result.Calories
+	}
+
+	return total
+}
+
+// TotalDuration returns the combined duration of every workout, in hours.
+func (s Session) TotalDuration() float64 {
+	var total float64
+	for _, w := range s.Workouts {
+		total += w.Result.Duration
+	}
+
+	return total
+}
+
+// AveragePace returns the session's average pace, in decimal minutes per
+// kilometer, or 0 if it covered no distance.
+func (s Session) AveragePace() float64 {
+	distance := s.TotalDistance()
+	if distance == 0 {
+		return 0
+	}
+
+	return s.TotalDuration() * minInH / distance
+}
+
+// WeekStats summarizes one calendar week (Monday-Sunday) of a Session's workouts.
+type WeekStats struct {
+	WeekStart time.Time
+	Distance  float64
+	Calories  float64
+	Duration  float64
+	Load      float64
+}
+
+// WeeklySummary buckets every workout on or before now into the calendar
+// week it falls in and totals each week's distance, calories, duration and
+// training load, ordered from oldest to newest week.
+//
+// *** parameters ***
+//
+// now time.Time — workouts logged after now are excluded.
+func (s Session) WeeklySummary(now time.Time) []WeekStats {
+	buckets := make(map[time.Time]*WeekStats)
+	var order []time.Time
+
+	for _, w := range s.Workouts {
+		if w.Date.After(now) {
+			continue
+		}
+
+		weekStart := startOfWeek(w.Date)
+		stats, ok := buckets[weekStart]
+		if !ok {
+			stats = &WeekStats{WeekStart: weekStart}
+			buckets[weekStart] = stats
+			order = append(order, weekStart)
+		}
+
+		stats.Distance += w.Result.Distance
+		stats.Calories += w.Result.Calories
+		stats.Duration += w.Result.Duration
+		stats.Load += w.trimp()
+	}
+
+	sort.Slice(order, func(i, j int) bool { return order[i].Before(order[j]) })
+
+	summary := make([]WeekStats, len(order))
+	for i, week := range order {
+		summary[i] = *buckets[week]
+	}
+
+	return summary
+}
+
+// startOfWeek returns midnight on the Monday of t's week.
+func startOfWeek(t time.Time) time.Time {
+	weekday := int(t.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+
+	y, m, d := t.AddDate(0, 0, 1-weekday).Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// TrainingLoad summarizes overtraining risk as of now: the session's total
+// TRIMP (Training Impulse, per Banister), the acute (7-day) and chronic
+// (28-day) rolling daily averages, and their ratio (ACWR) — conventionally
+// 0.8-1.3 is considered safe, and values well above that flag rising injury risk.
+//
+// *** parameters ***
+//
+// now time.Time — the reference date for the acute/chronic rolling windows.
+func (s Session) TrainingLoad(now time.Time) (total, acute, chronic, acwr float64) {
+	for _, w := range s.Workouts {
+		total += w.trimp()
+	}
+
+	acute = s.averageDailyLoad(now, 7)
+	chronic = s.averageDailyLoad(now, 28)
+	if chronic != 0 {
+		acwr = acute / chronic
+	}
+
+	return total, acute, chronic, acwr
+}
+
+// averageDailyLoad averages TRIMP over the days-day window ending at now.
+func (s Session) averageDailyLoad(now time.Time, days int) float64 {
+	cutoff := now.AddDate(0, 0, -days)
+
+	var total float64
+	for _, w := range s.Workouts {
+		if w.Date.After(cutoff) && !w.Date.After(now) {
+			total += w.trimp()
+		}
+	}
+
+	return total / float64(days)
+}
+
+// sessionJSON is the on-disk representation LoadFromJSON and SaveJSON use.
+type sessionJSON struct {
+	Start    time.Time       `json:"start"`
+	End      time.Time       `json:"end"`
+	Workouts []LoggedWorkout `json:"workouts"`
+}
+
+// LoadFromJSON reads a Session previously written with SaveJSON.
+//
+// *** parameters ***
+//
+// r io.Reader — the JSON document to decode.
+func LoadFromJSON(r io.Reader) (*Session, error) {
+	var raw sessionJSON
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("ftracker: decoding session: %w", err)
+	}
+
+	return &Session{Start: raw.Start, End: raw.End, Workouts: raw.Workouts}, nil
+}
+
+// SaveJSON writes s to w in the format LoadFromJSON reads back.
+//
+// *** parameters ***
+//
+// w io.Writer — where to write the JSON document.
+func (s Session) SaveJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(sessionJSON{Start: s.Start, End: s.End, Workouts: s.Workouts})
+}
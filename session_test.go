@@ -0,0 +1,94 @@
+package ftracker
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLoggedWorkoutTrimp(t *testing.T) {
+	workout := func(hr *HRInfo) LoggedWorkout {
+		return LoggedWorkout{Result: TrainingResult{Duration: 1}, HR: hr}
+	}
+
+	t.Run("male", func(t *testing.T) {
+		hr := &HRInfo{Samples: []HRSample{{BPM: 150}}, Sex: SexMale, RestHR: 60, MaxHR: 190}
+		hrReserve := (150.0 - 60) / (190 - 60)
+		want := 60 * hrReserve * 0.64 * math.Exp(1.92*hrReserve)
+		if got := workout(hr).trimp(); got != want {
+			t.Errorf("trimp() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("female", func(t *testing.T) {
+		hr := &HRInfo{Samples: []HRSample{{BPM: 150}}, Sex: SexFemale, RestHR: 60, MaxHR: 190}
+		hrReserve := (150.0 - 60) / (190 - 60)
+		want := 60 * hrReserve * 0.86 * math.Exp(1.67*hrReserve)
+		if got := workout(hr).trimp(); got != want {
+			t.Errorf("trimp() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("no heart-rate data returns zero", func(t *testing.T) {
+		if got := workout(nil).trimp(); got != 0 {
+			t.Errorf("trimp() = %v, want 0", got)
+		}
+	})
+
+	t.Run("equal rest and max heart rate guards the divide by zero", func(t *testing.T) {
+		hr := &HRInfo{Samples: []HRSample{{BPM: 150}}, Sex: SexMale, RestHR: 60, MaxHR: 60}
+		if got := workout(hr).trimp(); got != 0 {
+			t.Errorf("trimp() = %v, want 0", got)
+		}
+	})
+
+	t.Run("no samples returns zero", func(t *testing.T) {
+		hr := &HRInfo{Samples: nil, Sex: SexMale, RestHR: 60, MaxHR: 190}
+		if got := workout(hr).trimp(); got != 0 {
+			t.Errorf("trimp() = %v, want 0", got)
+		}
+	})
+}
+
+func TestSessionTrainingLoad(t *testing.T) {
+	now := time.Date(2026, 1, 28, 0, 0, 0, 0, time.UTC)
+	hr := &HRInfo{Samples: []HRSample{{BPM: 150}}, Sex: SexMale, RestHR: 60, MaxHR: 190}
+
+	s := Session{Workouts: []LoggedWorkout{
+		{Result: TrainingResult{Duration: 1}, Date: now.AddDate(0, 0, -2), HR: hr},  // inside acute and chronic windows
+		{Result: TrainingResult{Duration: 1}, Date: now.AddDate(0, 0, -20), HR: hr}, // inside chronic only
+		{Result: TrainingResult{Duration: 1}, Date: now.AddDate(0, 0, -40), HR: hr}, // outside both windows
+	}}
+
+	perWorkout := s.Workouts[0].trimp()
+
+	total, acute, chronic, acwr := s.TrainingLoad(now)
+
+	wantTotal := 3 * perWorkout
+	if total != wantTotal {
+		t.Errorf("total = %v, want %v", total, wantTotal)
+	}
+
+	wantAcute := perWorkout / 7
+	if acute != wantAcute {
+		t.Errorf("acute = %v, want %v", acute, wantAcute)
+	}
+
+	wantChronic := 2 * perWorkout / 28
+	if chronic != wantChronic {
+		t.Errorf("chronic = %v, want %v", chronic, wantChronic)
+	}
+
+	wantACWR := wantAcute / wantChronic
+	if acwr != wantACWR {
+		t.Errorf("acwr = %v, want %v", acwr, wantACWR)
+	}
+}
+
+func TestSessionTrainingLoadNoHistoryHasZeroACWR(t *testing.T) {
+	s := Session{}
+	_, _, chronic, acwr := s.TrainingLoad(time.Now())
+	if chronic != 0 || acwr != 0 {
+		t.Errorf("chronic = %v, acwr = %v, want 0, 0", chronic, acwr)
+	}
+}
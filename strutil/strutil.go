@@ -0,0 +1,18 @@
+// Package strutil provides small string-formatting helpers shared across
+// the fitness tracker module and its subpackages.
+package strutil
+
+import "fmt"
+
+// DecimalTimeToMinSec renders a decimal time value (e.g. a pace in minutes
+// per kilometer/mile) as "M:SS".
+//
+// *** parameters ***
+//
+// decimal float64 — the decimal time value, e.g. 5.5 for five and a half minutes.
+func DecimalTimeToMinSec(decimal float64) string {
+	whole := int(decimal)
+	fraction := int((decimal - float64(whole)) * 60)
+
+	return fmt.Sprintf("%d:%02d", whole, fraction)
+}
@@ -0,0 +1,21 @@
+package strutil
+
+import "testing"
+
+func TestDecimalTimeToMinSec(t *testing.T) {
+	tests := []struct {
+		decimal float64
+		want    string
+	}{
+		{5.5, "5:30"},
+		{0, "0:00"},
+		{4.0, "4:00"},
+		{2.25, "2:15"},
+	}
+
+	for _, tt := range tests {
+		if got := DecimalTimeToMinSec(tt.decimal); got != tt.want {
+			t.Errorf("DecimalTimeToMinSec(%v) = %q, want %q", tt.decimal, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package ftracker
+
+import "fmt"
+
+// Training is the common interface implemented by every training discipline
+// this module knows how to calculate and describe.
+type Training interface {
+	// Type returns the training kind's display name, e.g. "Бег".
+	Type() string
+
+	// Duration returns the training duration, in hours.
+	Duration() float64
+
+	// Distance returns the distance covered during the training session, in kilometers.
+	Distance() float64
+
+	// MeanSpeed returns the average speed during the training session, in km/h.
+	MeanSpeed() float64
+
+	// SpentCalories returns the number of calories spent during the training session.
+	SpentCalories() float64
+
+	// Info returns a human-readable summary of the training session.
+	Info() string
+}
+
+// Factory creates a Training value from a set of named parameters. It is used
+// together with Register so callers can plug in custom training kinds without
+// modifying this package.
+//
+// *** parameters ***
+//
+// params map[string]float64 — the training parameters, keyed by name (e.g. "action", "duration", "weight").
+type Factory func(params map[string]float64) Training
+
+var registry = make(map[string]Factory)
+
+// Register adds a training kind under name so it can later be created via New.
+//
+// *** parameters ***
+//
+// name string — the training kind identifier, e.g. "Бег".
+//
+// factory Factory — builds a Training value from the parameters passed to New.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// New creates a Training value for a training kind previously added with Register.
+//
+// *** parameters ***
+//
+// name string — the training kind identifier, e.g. "Бег".
+//
+// params map[string]float64 — the training parameters passed through to the registered Factory.
+func New(name string, params map[string]float64) (Training, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("ftracker: unknown training type %q", name)
+	}
+
+	return factory(params), nil
+}
+
+func init() {
+	Register("Бег", func(p map[string]float64) Training {
+		return Running{Action: int(p["action"]), DurationHours: p["duration"], Weight: p["weight"]}
+	})
+	Register("Ходьба", func(p map[string]float64) Training {
+		return Walking{Action: int(p["action"]), DurationHours: p["duration"], Weight: p["weight"], Height: p["height"]}
+	})
+	Register("Плавание", func(p map[string]float64) Training {
+		return Swimming{LengthPool: int(p["lengthPool"]), CountPool: int(p["countPool"]), DurationHours: p["duration"], Weight: p["weight"]}
+	})
+	Register("Велоспорт", func(p map[string]float64) Training {
+		return Cycling{WheelRevolutions: int(p["wheelRevolutions"]), WheelCircumference: p["wheelCircumference"], DurationHours: p["duration"], Weight: p["weight"]}
+	})
+	Register("Гребля", func(p map[string]float64) Training {
+		return Rowing{Strokes: int(p["strokes"]), DurationHours: p["duration"], Weight: p["weight"]}
+	})
+	Register("Силовая тренировка", func(p map[string]float64) Training {
+		return StrengthTraining{Sets: int(p["sets"]), Reps: int(p["reps"]), Weight: p["weight"], DurationHours: p["duration"]}
+	})
+}
+
+// trainingInfo formats the shared distance/speed/calories report used by every
+// location-based training kind.
+func trainingInfo(trainingType string, duration, dist, speed, calories float64) string {
+	return fmt.Sprintf("Тип тренировки: %s\nДлительность: %.2f ч.\nДистанция: %.2f км.\nСкорость: %.2f км/ч\nСожгли калорий: %.2f\n", trainingType, duration, dist, speed, calories)
+}
+
+// Running represents a running training session, tracked by step count.
+type Running struct {
+	Action        int     // number of steps.
+	DurationHours float64 // training duration in hours.
+	Weight        float64 // user weight in kg.
+}
+
+func (r Running) Type() string       { return "Бег" }
+func (r Running) Duration() float64  { return r.DurationHours }
+func (r Running) Distance() float64  { return distance(r.Action) }
+func (r Running) MeanSpeed() float64 { return meanSpeed(r.Action, r.DurationHours) }
+func (r Running) SpentCalories() float64 {
+	return RunningSpentCalories(r.Action, r.Weight, r.DurationHours)
+}
+func (r Running) Info() string {
+	return trainingInfo(r.Type(), r.Duration(), r.Distance(), r.MeanSpeed(), r.SpentCalories())
+}
+
+// Walking represents a walking training session, tracked by step count.
+type Walking struct {
+	Action        int     // number of steps.
+	DurationHours float64 // training duration in hours.
+	Weight        float64 // user weight in kg.
+	Height        float64 // user height in m.
+}
+
+func (w Walking) Type() string       { return "Ходьба" }
+func (w Walking) Duration() float64  { return w.DurationHours }
+func (w Walking) Distance() float64  { return distance(w.Action) }
+func (w Walking) MeanSpeed() float64 { return meanSpeed(w.Action, w.DurationHours) }
+func (w Walking) SpentCalories() float64 {
+	return WalkingSpentCalories(w.Action, w.DurationHours, w.Weight, w.Height)
+}
+func (w Walking) Info() string {
+	return trainingInfo(w.Type(), w.Duration(), w.Distance(), w.MeanSpeed(), w.SpentCalories())
+}
+
+// Swimming represents a pool swimming training session.
+type Swimming struct {
+	LengthPool    int     // pool length in meters.
+	CountPool     int     // how many times the user swam across the pool.
+	DurationHours float64 // training duration in hours.
+	Weight        float64 // user weight in kg.
+}
+
+func (s Swimming) Type() string      { return "Плавание" }
+func (s Swimming) Duration() float64 { return s.DurationHours }
+func (s Swimming) Distance() float64 { return float64(s.LengthPool*s.CountPool) / mInKm }
+func (s Swimming) MeanSpeed() float64 {
+	return swimmingMeanSpeed(s.LengthPool, s.CountPool, s.DurationHours)
+}
+func (s Swimming) SpentCalories() float64 {
+	return SwimmingSpentCalories(s.LengthPool, s.CountPool, s.DurationHours, s.Weight)
+}
+func (s Swimming) Info() string {
+	return trainingInfo(s.Type(), s.Duration(), s.Distance(), s.MeanSpeed(), s.SpentCalories())
+}
+
+// Constants for calculating distance and calories during cycling.
+const (
+	cyclingCaloriesMET = 8.0 // metabolic equivalent for moderate-effort cycling, kcal/(kg·h).
+)
+
+// Cycling represents a cycling training session, tracked by wheel revolutions
+// instead of steps.
+type Cycling struct {
+	WheelRevolutions   int     // number of full wheel revolutions.
+	WheelCircumference float64 // wheel circumference in meters.
+	DurationHours      float64 // training duration in hours.
+	Weight             float64 // user weight in kg.
+}
+
+func (c Cycling) Type() string      { return "Велоспорт" }
+func (c Cycling) Duration() float64 { return c.DurationHours }
+func (c Cycling) Distance() float64 {
+	return float64(c.WheelRevolutions) * c.WheelCircumference / mInKm
+}
+func (c Cycling) MeanSpeed() float64 {
+	if c.DurationHours == 0 {
+		return 0
+	}
+
+	return c.Distance() / c.DurationHours
+}
+func (c Cycling) SpentCalories() float64 {
+	return cyclingCaloriesMET * c.Weight * c.DurationHours
+}
+func (c Cycling) Info() string {
+	return trainingInfo(c.Type(), c.Duration(), c.Distance(), c.MeanSpeed(), c.SpentCalories())
+}
+
+// Constants for calculating distance and calories during rowing.
+const (
+	rowingStrokeLength             = 6   // average distance covered per stroke, in meters.
+	rowingCaloriesMeanSpeedShift   = 1.3 // the average number of calories burned while rowing relative to speed.
+	rowingCaloriesWeightMultiplier = 2.5 // rowing weight multiplier.
+)
+
+// Rowing represents an open-water rowing training session, tracked by stroke
+// count the same way Swimming is tracked by pool lengths.
+type Rowing struct {
+	Strokes       int     // number of strokes.
+	DurationHours float64 // training duration in hours.
+	Weight        float64 // user weight in kg.
+}
+
+func (r Rowing) Type() string      { return "Гребля" }
+func (r Rowing) Duration() float64 { return r.DurationHours }
+func (r Rowing) Distance() float64 {
+	return float64(r.Strokes) * rowingStrokeLength / mInKm
+}
+func (r Rowing) MeanSpeed() float64 {
+	if r.DurationHours == 0 {
+		return 0
+	}
+
+	return r.Distance() / r.DurationHours
+}
+func (r Rowing) SpentCalories() float64 {
+	return (r.MeanSpeed() + rowingCaloriesMeanSpeedShift) * rowingCaloriesWeightMultiplier * r.Weight * r.DurationHours
+}
+func (r Rowing) Info() string {
+	return trainingInfo(r.Type(), r.Duration(), r.Distance(), r.MeanSpeed(), r.SpentCalories())
+}
+
+// Constants for calculating calories spent during strength training.
+const (
+	strengthCaloriesPerRepKg = 0.05 // kcal burned per repetition, per kg lifted.
+)
+
+// StrengthTraining represents a location-less, repetition-based training
+// session such as weightlifting, where distance and speed do not apply.
+// RepetitionWorkout is an alias kept for callers that prefer that name.
+type StrengthTraining struct {
+	Sets          int     // number of sets.
+	Reps          int     // repetitions per set.
+	Weight        float64 // weight lifted per repetition, in kg.
+	DurationHours float64 // training duration in hours.
+}
+
+// RepetitionWorkout is an alias for StrengthTraining.
+type RepetitionWorkout = StrengthTraining
+
+func (s StrengthTraining) Type() string       { return "Силовая тренировка" }
+func (s StrengthTraining) Duration() float64  { return s.DurationHours }
+func (s StrengthTraining) Distance() float64  { return 0 }
+func (s StrengthTraining) MeanSpeed() float64 { return 0 }
+func (s StrengthTraining) SpentCalories() float64 {
+	return float64(s.Sets*s.Reps) * s.Weight * strengthCaloriesPerRepKg
+}
+func (s StrengthTraining) Info() string {
+	return fmt.Sprintf("Тип тренировки: %s\nДлительность: %.2f ч.\nСожгли калорий: %.2f\n", s.Type(), s.Duration(), s.SpentCalories())
+}
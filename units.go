@@ -0,0 +1,32 @@
+package ftracker
+
+// Units selects which measurement system a Formatter renders distances and
+// speeds in.
+type Units int
+
+// Supported values for Units.
+const (
+	Metric Units = iota
+	Imperial
+)
+
+// kmToMi is the conversion factor from kilometers to miles.
+const kmToMi = 0.621371
+
+// convertDistance converts a distance in kilometers to u.
+func convertDistance(km float64, u Units) float64 {
+	if u == Imperial {
+		return km * kmToMi
+	}
+
+	return km
+}
+
+// convertSpeed converts a speed in km/h to u.
+func convertSpeed(kmh float64, u Units) float64 {
+	if u == Imperial {
+		return kmh * kmToMi
+	}
+
+	return kmh
+}